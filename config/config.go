@@ -0,0 +1,70 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package config holds the settings that control how ivy formats,
+// evaluates, and compares values. A *Config is threaded through
+// execution via exec.Context so that every package can see the
+// current settings without relying on globals.
+package config
+
+import "fmt"
+
+// ComplexOrder selects the rule value.OrderedCompare uses to order
+// Complex values against each other and against real scalars.
+type ComplexOrder int
+
+const (
+	// ComplexOrderLex orders by real part, then by imaginary part.
+	// This is ivy's historical behavior and the default.
+	ComplexOrderLex ComplexOrder = iota
+	// ComplexOrderMagnitude orders by |z|, breaking ties by argument.
+	ComplexOrderMagnitude
+	// ComplexOrderTotal applies an IEEE 754 totalOrder to each
+	// component, giving a deterministic order that also covers
+	// signed zeros and NaN payloads.
+	ComplexOrderTotal
+)
+
+func (o ComplexOrder) String() string {
+	switch o {
+	case ComplexOrderLex:
+		return "lex"
+	case ComplexOrderMagnitude:
+		return "magnitude"
+	case ComplexOrderTotal:
+		return "total"
+	}
+	return "unknown"
+}
+
+// Config carries the settings shared across a single ivy execution.
+type Config struct {
+	complexOrder ComplexOrder
+}
+
+// ComplexOrder returns the ordering mode set by SetComplexOrder. The
+// zero value of Config orders complex numbers lexicographically, so
+// callers that never call SetComplexOrder see ivy's historical
+// behavior.
+func (c *Config) ComplexOrder() ComplexOrder {
+	return c.complexOrder
+}
+
+// SetComplexOrder selects the rule value.OrderedCompare uses to order
+// Complex values against each other and against real scalars. mode
+// must be one of "lex", "magnitude", or "total"; any other value
+// returns an error and leaves the current setting unchanged.
+func (c *Config) SetComplexOrder(mode string) error {
+	switch mode {
+	case "lex":
+		c.complexOrder = ComplexOrderLex
+	case "magnitude":
+		c.complexOrder = ComplexOrderMagnitude
+	case "total":
+		c.complexOrder = ComplexOrderTotal
+	default:
+		return fmt.Errorf("config: unknown complex order %q", mode)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"robpike.io/ivy/config"
+	"robpike.io/ivy/exec"
+	"robpike.io/ivy/value"
+)
+
+func TestDiffVector(t *testing.T) {
+	a := value.Vector{int1, int2, int3}
+	b := value.Vector{int1, int2, int0}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	report := value.Diff(c, a, b)
+	if report.ShapeMismatch() {
+		t.Fatalf("unexpected shape mismatch")
+	}
+	if len(report.Entries) != 1 {
+		t.Fatalf("got %d mismatched entries, want 1", len(report.Entries))
+	}
+	e := report.Entries[0]
+	if e.Index[0] != 2 || e.Cmp != 1 {
+		t.Errorf("entry = %+v, want index 2, cmp 1", e)
+	}
+	three := big.NewFloat(3)
+	if report.MinAbsDiff == nil || report.MinAbsDiff.Cmp(three) != 0 {
+		t.Errorf("MinAbsDiff = %v, want 3", report.MinAbsDiff)
+	}
+	if report.MaxAbsDiff == nil || report.MaxAbsDiff.Cmp(three) != 0 {
+		t.Errorf("MaxAbsDiff = %v, want 3", report.MaxAbsDiff)
+	}
+}
+
+func TestDiffMixedTypes(t *testing.T) {
+	// Each pair of corresponding elements is equal in value (1, 0, 1)
+	// even though the two elements of a pair differ in Go type.
+	a := value.Vector{int1, bigInt0, bigRat1o1}
+	b := value.Vector{bigInt1, int0, bigFloat1p0}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	report := value.Diff(c, a, b)
+	if !report.Equal() {
+		t.Errorf("Diff(%v, %v) = %v, want Equal", a, b, report)
+	}
+}
+
+func TestDiffShapeMismatch(t *testing.T) {
+	a := value.Vector{int1, int2}
+	b := value.Vector{int1, int2, int3}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	report := value.Diff(c, a, b)
+	if !report.ShapeMismatch() {
+		t.Errorf("Diff(%v, %v): ShapeMismatch() = false, want true", a, b)
+	}
+	if len(report.Entries) != 0 {
+		t.Errorf("Diff on mismatched shapes recorded %d entries, want 0", len(report.Entries))
+	}
+}
+
+func TestDiffMatrixString(t *testing.T) {
+	a := value.Matrix{Rows: []value.Vector{{int1, int2}, {int3, int1}}}
+	b := value.Matrix{Rows: []value.Vector{{int1, int0}, {int3, int1}}}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	report := value.Diff(c, a, b)
+	got := report.String()
+	if !strings.Contains(got, "2 2 ρ A vs B: 1 cell(s) differ") {
+		t.Errorf("String() = %q, want header for a single differing cell", got)
+	}
+	if !strings.Contains(got, "[0 1]") {
+		t.Errorf("String() = %q, want the mismatched cell's index [0 1]", got)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exec drives evaluation of ivy programs: it holds the
+// execution context and, in time, the variable and function
+// bindings a running program sees.
+package exec
+
+import "robpike.io/ivy/config"
+
+// Context holds the state shared across a single ivy execution. It
+// satisfies value.Context, so it can be passed directly to functions
+// such as value.OrderedCompare.
+type Context struct {
+	config *config.Config
+}
+
+// NewContext returns a new Context backed by conf. conf is shared,
+// not copied, so changes made through conf (such as
+// conf.SetComplexOrder) take effect immediately for this Context.
+func NewContext(conf *config.Config) *Context {
+	return &Context{config: conf}
+}
+
+// Config returns the Config driving this Context.
+func (c *Context) Config() *config.Config {
+	return c.config
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"robpike.io/ivy/config"
+	"robpike.io/ivy/exec"
+	"robpike.io/ivy/value"
+)
+
+// TestGradeVector exercises ⍋/⍒ on a vector mixing every scalar kind
+// OrderedCompare can order together: Int, Char, BigInt, BigRat,
+// BigFloat, and Complex.
+func TestGradeVector(t *testing.T) {
+	v := value.Vector{int2, char1, bigFloat1p5, bigInt0, complex1j1, bigRat1o7}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	up := value.Grade(c, v, true, nil)
+	wantUp := value.Vector{value.Int(2), value.Int(4), value.Int(6), value.Int(5), value.Int(3), value.Int(1)}
+	if !reflect.DeepEqual(up, wantUp) {
+		t.Errorf("grade up = %v, want %v", up, wantUp)
+	}
+
+	down := value.Grade(c, v, false, nil)
+	wantDown := value.Vector{value.Int(1), value.Int(3), value.Int(5), value.Int(6), value.Int(4), value.Int(2)}
+	if !reflect.DeepEqual(down, wantDown) {
+		t.Errorf("grade down = %v, want %v", down, wantDown)
+	}
+}
+
+// TestGradeMatrix checks that grading a Matrix compares rows
+// lexicographically, using the first column as the primary key.
+func TestGradeMatrix(t *testing.T) {
+	m := value.Matrix{Rows: []value.Vector{
+		{int2, int1},
+		{int1, int2},
+		{int1, int1},
+		{int2, int2},
+	}}
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	up := value.Grade(c, m, true, nil)
+	want := value.Vector{value.Int(3), value.Int(2), value.Int(1), value.Int(4)}
+	if !reflect.DeepEqual(up, want) {
+		t.Errorf("grade up = %v, want %v", up, want)
+	}
+}
+
+// TestGradeCollate checks the ⍋[C] form: a custom collating sequence
+// overrides the default code-point order for Char elements.
+func TestGradeCollate(t *testing.T) {
+	v := value.Vector{char1, char2, char3}
+	collate := value.Vector{char3, char1, char2} // char3 < char1 < char2
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+
+	up := value.Grade(c, v, true, collate)
+	want := value.Vector{value.Int(3), value.Int(1), value.Int(2)}
+	if !reflect.DeepEqual(up, want) {
+		t.Errorf("grade up with collation = %v, want %v", up, want)
+	}
+}
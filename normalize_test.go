@@ -0,0 +1,84 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"robpike.io/ivy/config"
+	"robpike.io/ivy/exec"
+	"robpike.io/ivy/value"
+)
+
+func TestNormalize(t *testing.T) {
+	var tests = []struct {
+		in   value.Value
+		want value.Value
+	}{
+		// BigInt that fits in an Int demotes; one that doesn't stays put.
+		{value.BigInt{big.NewInt(1)}, value.Int(1)},
+		{value.BigInt{new(big.Int).Lsh(big.NewInt(1), 100)}, value.BigInt{new(big.Int).Lsh(big.NewInt(1), 100)}},
+
+		// BigRat with denominator 1 demotes all the way to Int; a
+		// genuine fraction does not.
+		{value.BigRat{big.NewRat(4, 1)}, value.Int(4)},
+		{value.BigRat{big.NewRat(1, 3)}, value.BigRat{big.NewRat(1, 3)}},
+
+		// BigFloat that is exactly integral demotes; one with a
+		// fractional part does not.
+		{value.BigFloat{big.NewFloat(5.0)}, value.Int(5)},
+		{value.BigFloat{big.NewFloat(5.5)}, value.BigFloat{big.NewFloat(5.5)}},
+
+		// Complex with a zero imaginary part collapses to its real
+		// component, recursively normalized; with a nonzero imaginary
+		// part it is left alone.
+		{value.NewComplex(value.BigInt{big.NewInt(3)}, value.Int(0)), value.Int(3)},
+		{value.NewComplex(value.Int(3), value.Int(1)), value.NewComplex(value.Int(3), value.Int(1))},
+	}
+	for _, test := range tests {
+		got := value.Normalize(test.in)
+		if got.Kind() != test.want.Kind() || got.String() != test.want.String() {
+			t.Errorf("Normalize(%v) = %v (%v), want %v (%v)", test.in, got, got.Kind(), test.want, test.want.Kind())
+		}
+	}
+}
+
+// mixedTypeArray builds a slice holding the numbers 0 through n-1,
+// cycling through Int, BigInt, BigRat, and zero-imaginary Complex
+// representations, the kind of heterogeneous array ivy arithmetic
+// produces once it mixes representations.
+func mixedTypeArray(n int) []value.Value {
+	a := make([]value.Value, n)
+	for i := range a {
+		switch i % 4 {
+		case 0:
+			a[i] = value.Int(int64(i))
+		case 1:
+			a[i] = value.BigInt{big.NewInt(int64(i))}
+		case 2:
+			a[i] = value.BigRat{big.NewRat(int64(i), 1)}
+		case 3:
+			a[i] = value.NewComplex(value.Int(int64(i)), value.Int(0))
+		}
+	}
+	return a
+}
+
+// BenchmarkOrderedCompareMixed measures OrderedCompare over a mixed-type
+// array; since compareNumeric normalizes its operands, most of these
+// comparisons take the Int/Int fast path instead of promoting through
+// big.Float.
+func BenchmarkOrderedCompareMixed(b *testing.B) {
+	var testConf config.Config
+	c := exec.NewContext(&testConf)
+	a := mixedTypeArray(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 1; j < len(a); j++ {
+			value.OrderedCompare(c, a[j-1], a[j])
+		}
+	}
+}
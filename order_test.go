@@ -5,6 +5,7 @@
 package main
 
 import (
+	"math"
 	"math/big"
 	"testing"
 
@@ -52,185 +53,375 @@ var (
 	complex1j2 = value.NewComplex(int1, int2) // Same real, bigger imaginary.
 	complex2j1 = value.NewComplex(int2, int1) // Bigger real, lesser imaginary
 	complex2j2 = value.NewComplex(int2, int2) // Same real, bigger imaginary
+
+	bigFloatNeg0  = value.BigFloat{new(big.Float).SetFloat64(math.Copysign(0, -1))}
+	complexNeg0j0 = value.NewComplex(bigFloatNeg0, int0)
+	complexPos0j0 = value.NewComplex(bigFloat0p0, int0)
 )
 
+// commonTests hold relations that do not depend on the configured
+// config.ComplexOrder: comparisons among real scalars, and between a
+// real scalar and a Complex whose imaginary part is zero.
+var commonTests = []orderTest{
+	// Same types.
+	// Int
+	{int1, int1, 0},
+	{int1, int2, -1},
+	{int1, int3, -1},
+	{int2, int1, 1},
+	{int2, int2, 0},
+	{int2, int3, -1},
+	{int3, int1, 1},
+	{int3, int2, 1},
+	{int3, int3, 0},
+
+	// Char
+	{char1, char1, 0},
+	{char1, char2, -1},
+	{char1, char3, -1},
+	{char2, char1, 1},
+	{char2, char2, 0},
+	{char2, char3, -1},
+	{char3, char1, 1},
+	{char3, char2, 1},
+	{char3, char3, 0},
+
+	// BigInt
+	{bigInt1, bigInt1, 0},
+	{bigInt1, bigInt2, -1},
+	{bigInt1, bigInt3, -1},
+	{bigInt2, bigInt1, 1},
+	{bigInt2, bigInt2, 0},
+	{bigInt2, bigInt3, -1},
+	{bigInt3, bigInt1, 1},
+	{bigInt3, bigInt2, 1},
+	{bigInt3, bigInt3, 0},
+
+	// BigRat
+	{bigRat1o7, bigRat1o7, 0},
+	{bigRat1o7, bigRat2o7, -1},
+	{bigRat1o7, bigRat3o7, -1},
+	{bigRat2o7, bigRat1o7, 1},
+	{bigRat2o7, bigRat2o7, 0},
+	{bigRat2o7, bigRat3o7, -1},
+	{bigRat3o7, bigRat1o7, 1},
+	{bigRat3o7, bigRat2o7, 1},
+	{bigRat3o7, bigRat3o7, 0},
+
+	// BigFloat
+	{bigFloat1p5, bigFloat1p5, 0},
+	{bigFloat1p5, bigFloat2p5, -1},
+	{bigFloat1p5, bigFloat3p5, -1},
+	{bigFloat2p5, bigFloat1p5, 1},
+	{bigFloat2p5, bigFloat2p5, 0},
+	{bigFloat2p5, bigFloat3p5, -1},
+	{bigFloat3p5, bigFloat1p5, 1},
+	{bigFloat3p5, bigFloat2p5, 1},
+	{bigFloat3p5, bigFloat3p5, 0},
+
+	// Int less than every possible type.
+	{int0, bigInt1, -1},
+	{int0, bigRat1o1, -1},
+	{int0, bigFloat1p0, -1},
+	{int0, complex1j0, -1},
+
+	// Int equal to every possible type.
+	{int1, bigInt1, 0},
+	{int1, bigRat1o1, 0},
+	{int1, bigFloat1p0, 0},
+	{int1, complex1j0, 0},
+
+	// Int greater than every possible type.
+	{int2, bigInt1, 1},
+	{int2, bigRat1o1, 1},
+	{int2, bigFloat1p0, 1},
+	{int2, complex1j0, 1},
+
+	// BigInt less than every possible type.
+	{bigInt0, int1, -1},
+	{bigInt0, bigRat1o1, -1},
+	{bigInt0, bigFloat1p0, -1},
+	{bigInt0, complex1j0, -1},
+
+	// BigInt equal to every possible type.
+	{bigInt1, int1, 0},
+	{bigInt1, bigRat1o1, 0},
+	{bigInt1, bigFloat1p0, 0},
+	{bigInt1, complex1j0, 0},
+
+	// BigInt greater than every possible type.
+	{bigInt2, int1, 1},
+	{bigInt2, bigRat1o1, 1},
+	{bigInt2, bigFloat1p0, 1},
+	{bigInt2, complex1j0, 1},
+
+	// BigRat less than every possible type.
+	{bigRat0o1, int1, -1},
+	{bigRat0o1, bigInt1, -1},
+	{bigRat0o1, bigFloat1p0, -1},
+	{bigRat0o1, complex1j0, -1},
+
+	// BigRat equal to every possible type.
+	{bigRat1o1, int1, 0},
+	{bigRat1o1, bigInt1, 0},
+	{bigRat1o1, bigFloat1p0, 0},
+	{bigRat1o1, complex1j0, 0},
+
+	// BigRat greater than every possible type.
+	{bigRat2o1, int1, 1},
+	{bigRat2o1, bigInt1, 1},
+	{bigRat2o1, bigFloat1p0, 1},
+	{bigRat2o1, complex1j0, 1},
+
+	// BigFloat less than every possible type.
+	{bigFloat0p0, int1, -1},
+	{bigFloat0p0, bigInt1, -1},
+	{bigFloat0p0, bigFloat1p0, -1},
+	{bigFloat0p0, complex1j0, -1},
+
+	// BigFloat equal to every possible type.
+	{bigFloat1p0, int1, 0},
+	{bigFloat1p0, bigInt1, 0},
+	{bigFloat1p0, bigFloat1p0, 0},
+	{bigFloat1p0, complex1j0, 0},
+
+	// BigFloat greater than every possible type.
+	{bigFloat2p0, int1, 1},
+	{bigFloat2p0, bigInt1, 1},
+	{bigFloat2p0, bigFloat1p0, 1},
+	{bigFloat2p0, complex1j0, 1},
+
+	// Special cases involving char and complex.
+
+	// Char is always less than every other type.
+	{char1, int1, -1},
+	{char1, bigInt1, -1},
+	{char1, bigRat1o1, -1},
+	{char1, bigFloat1p0, -1},
+	{char1, complex1j0, -1},
+
+	// Complex that is actually real compares like a float for these
+	// nonnegative values under every configured complex order.
+	{complex1j0, int1, 0},
+	{complex1j0, char1, 1}, // Note: can't compare with char. See next block of tests.
+	{complex1j0, bigInt1, 0},
+	{complex1j0, bigRat1o1, 0},
+	{complex1j0, bigFloat1p0, 0},
+}
+
+// lexTests hold relations specific to config.ComplexOrderLex: a
+// Complex with a nonzero imaginary part compares by real part, then
+// by imaginary part.
+var lexTests = []orderTest{
+	{complex1j1, complex1j1, 0},
+	{complex1j1, complex1j2, -1},
+	{complex1j1, complex2j1, -1},
+	{complex1j1, complex2j2, -1},
+	{complex1j2, complex1j1, 1},
+	{complex1j2, complex1j2, 0},
+	{complex1j2, complex2j1, -1},
+	{complex1j2, complex2j2, -1},
+	{complex2j1, complex1j1, 1},
+	{complex2j1, complex1j2, 1},
+	{complex2j1, complex2j1, 0},
+	{complex2j1, complex2j2, -1},
+	{complex2j2, complex1j1, 1},
+	{complex2j2, complex1j2, 1},
+	{complex2j2, complex2j1, 1},
+	{complex2j2, complex2j2, 0},
+
+	// A Complex with a nonzero imaginary part equal to its real part's
+	// scalar counterpart is greater, since the imaginary part breaks
+	// the tie.
+	{complex1j1, int1, 1},
+	{complex1j1, char1, 1},
+	{complex1j1, bigInt1, 1},
+	{complex1j1, bigRat1o1, 1},
+	{complex1j1, bigFloat1p0, 1},
+
+	// -0 and +0 have the same mathematical value, so they compare equal.
+	{complexNeg0j0, complexPos0j0, 0},
+}
+
+// magnitudeTests hold relations specific to config.ComplexOrderMagnitude:
+// a Complex compares by |z|² = re²+im², ties broken by argument
+// atan2(im, re).
+var magnitudeTests = []orderTest{
+	{complex1j1, complex1j1, 0}, // |z|² = 2
+	{complex1j1, complex2j1, -1},
+	{complex1j1, complex1j2, -1},
+	{complex1j1, complex2j2, -1}, // |z|² = 8
+
+	// complex2j1 and complex1j2 both have |z|² = 5; complex2j1 has the
+	// smaller argument (atan2(1,2) < atan2(2,1)), so it sorts first —
+	// the opposite of their lexicographic order.
+	{complex2j1, complex1j1, 1},
+	{complex2j1, complex2j1, 0},
+	{complex2j1, complex1j2, -1},
+	{complex2j1, complex2j2, -1},
+	{complex1j2, complex1j1, 1},
+	{complex1j2, complex2j1, 1},
+	{complex1j2, complex1j2, 0},
+	{complex1j2, complex2j2, -1},
+	{complex2j2, complex1j1, 1},
+	{complex2j2, complex2j1, 1},
+	{complex2j2, complex1j2, 1},
+	{complex2j2, complex2j2, 0},
+
+	// |z|² = 2 is greater than 1² = 1²+0², so complex1j1 is greater than
+	// int1, but less than int2 (|z|² = 4): magnitude, not the
+	// lexicographic real part, decides the order.
+	{complex1j1, int1, 1},
+	{complex1j1, int2, -1},
+}
+
+// totalTests hold relations specific to config.ComplexOrderTotal: an
+// IEEE 754 totalOrder applied to each component, which (unlike "lex")
+// distinguishes -0 from +0, ordering -0 first.
+var totalTests = []orderTest{
+	{complex1j1, complex1j1, 0},
+	{complex1j1, complex1j2, -1},
+	{complex1j1, int1, 1},
+
+	{complexNeg0j0, complexPos0j0, -1},
+	{complexPos0j0, complexNeg0j0, 1},
+}
+
+func mustParseSemVer(s string) value.SemVer {
+	v, err := value.ParseSemVer(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+var (
+	semverAlpha     = mustParseSemVer("1.0.0-alpha")
+	semverAlpha1    = mustParseSemVer("1.0.0-alpha.1")
+	semverAlphaBeta = mustParseSemVer("1.0.0-alpha.beta")
+	semverBeta      = mustParseSemVer("1.0.0-beta")
+	semverRC1       = mustParseSemVer("1.0.0-rc.1")
+	semverRelease   = mustParseSemVer("1.0.0")
+)
+
+// semverTests cover the precedence chain from the semver.org spec:
+// 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-alpha.beta < 1.0.0-beta <
+// 1.0.0-rc.1 < 1.0.0.
+var semverTests = []orderTest{
+	{semverAlpha, semverAlpha, 0},
+	{semverAlpha, semverAlpha1, -1},
+	{semverAlpha, semverAlphaBeta, -1},
+	{semverAlpha, semverBeta, -1},
+	{semverAlpha, semverRC1, -1},
+	{semverAlpha, semverRelease, -1},
+
+	{semverAlpha1, semverAlpha, 1},
+	{semverAlpha1, semverAlpha1, 0},
+	{semverAlpha1, semverAlphaBeta, -1},
+	{semverAlpha1, semverRelease, -1},
+
+	{semverAlphaBeta, semverAlpha1, 1},
+	{semverAlphaBeta, semverAlphaBeta, 0},
+	{semverAlphaBeta, semverBeta, -1},
+
+	{semverBeta, semverAlphaBeta, 1},
+	{semverBeta, semverBeta, 0},
+	{semverBeta, semverRC1, -1},
+
+	{semverRC1, semverBeta, 1},
+	{semverRC1, semverRC1, 0},
+	{semverRC1, semverRelease, -1},
+
+	{semverRelease, semverRC1, 1},
+	{semverRelease, semverRelease, 0},
+
+	// Build metadata is preserved for display but ignored for ordering.
+	{mustParseSemVer("1.2.3+build.1"), mustParseSemVer("1.2.3+build.2"), 0},
+}
+
 func TestOrderedCompare(t *testing.T) {
-	var tests = []orderTest{
-		// Same types.
-		// Int
-		{int1, int1, 0},
-		{int1, int2, -1},
-		{int1, int3, -1},
-		{int2, int1, 1},
-		{int2, int2, 0},
-		{int2, int3, -1},
-		{int3, int1, 1},
-		{int3, int2, 1},
-		{int3, int3, 0},
-
-		// Char
-		{char1, char1, 0},
-		{char1, char2, -1},
-		{char1, char3, -1},
-		{char2, char1, 1},
-		{char2, char2, 0},
-		{char2, char3, -1},
-		{char3, char1, 1},
-		{char3, char2, 1},
-		{char3, char3, 0},
-
-		// BigInt
-		{bigInt1, bigInt1, 0},
-		{bigInt1, bigInt2, -1},
-		{bigInt1, bigInt3, -1},
-		{bigInt2, bigInt1, 1},
-		{bigInt2, bigInt2, 0},
-		{bigInt2, bigInt3, -1},
-		{bigInt3, bigInt1, 1},
-		{bigInt3, bigInt2, 1},
-		{bigInt3, bigInt3, 0},
-
-		// BigRat
-		{bigRat1o7, bigRat1o7, 0},
-		{bigRat1o7, bigRat2o7, -1},
-		{bigRat1o7, bigRat3o7, -1},
-		{bigRat2o7, bigRat1o7, 1},
-		{bigRat2o7, bigRat2o7, 0},
-		{bigRat2o7, bigRat3o7, -1},
-		{bigRat3o7, bigRat1o7, 1},
-		{bigRat3o7, bigRat2o7, 1},
-		{bigRat3o7, bigRat3o7, 0},
-
-		// BigFloat
-		{bigFloat1p5, bigFloat1p5, 0},
-		{bigFloat1p5, bigFloat2p5, -1},
-		{bigFloat1p5, bigFloat3p5, -1},
-		{bigFloat2p5, bigFloat1p5, 1},
-		{bigFloat2p5, bigFloat2p5, 0},
-		{bigFloat2p5, bigFloat3p5, -1},
-		{bigFloat3p5, bigFloat1p5, 1},
-		{bigFloat3p5, bigFloat2p5, 1},
-		{bigFloat3p5, bigFloat3p5, 0},
-
-		// Complex
-		{complex1j1, complex1j1, 0},
-		{complex1j1, complex1j2, -1},
-		{complex1j1, complex2j1, -1},
-		{complex1j1, complex2j2, -1},
-		{complex1j2, complex1j1, 1},
-		{complex1j2, complex1j2, 0},
-		{complex1j2, complex2j1, -1},
-		{complex1j2, complex2j2, -1},
-		{complex2j1, complex1j1, 1},
-		{complex2j1, complex1j2, 1},
-		{complex2j1, complex2j1, 0},
-		{complex2j1, complex2j2, -1},
-		{complex2j2, complex1j1, 1},
-		{complex2j2, complex1j2, 1},
-		{complex2j2, complex2j1, 1},
-		{complex2j2, complex2j2, 0},
-
-		// Int less than every possible type.
-		{int0, bigInt1, -1},
-		{int0, bigRat1o1, -1},
-		{int0, bigFloat1p0, -1},
-		{int0, complex1j0, -1},
-
-		// Int equal to every possible type.
-		{int1, bigInt1, 0},
-		{int1, bigRat1o1, 0},
-		{int1, bigFloat1p0, 0},
-		{int1, complex1j0, 0},
-
-		// Int greater than every possible type.
-		{int2, bigInt1, 1},
-		{int2, bigRat1o1, 1},
-		{int2, bigFloat1p0, 1},
-		{int2, complex1j0, 1},
-
-		// BigInt less than every possible type.
-		{bigInt0, int1, -1},
-		{bigInt0, bigRat1o1, -1},
-		{bigInt0, bigFloat1p0, -1},
-		{bigInt0, complex1j0, -1},
-
-		// BigInt equal to every possible type.
-		{bigInt1, int1, 0},
-		{bigInt1, bigRat1o1, 0},
-		{bigInt1, bigFloat1p0, 0},
-		{bigInt1, complex1j0, 0},
-
-		// BigInt greater than every possible type.
-		{bigInt2, int1, 1},
-		{bigInt2, bigRat1o1, 1},
-		{bigInt2, bigFloat1p0, 1},
-		{bigInt2, complex1j0, 1},
-
-		// BigRat less than every possible type.
-		{bigRat0o1, int1, -1},
-		{bigRat0o1, bigInt1, -1},
-		{bigRat0o1, bigFloat1p0, -1},
-		{bigRat0o1, complex1j0, -1},
-
-		// BigRat equal to every possible type.
-		{bigRat1o1, int1, 0},
-		{bigRat1o1, bigInt1, 0},
-		{bigRat1o1, bigFloat1p0, 0},
-		{bigRat1o1, complex1j0, 0},
-
-		// BigRat greater than every possible type.
-		{bigRat2o1, int1, 1},
-		{bigRat2o1, bigInt1, 1},
-		{bigRat2o1, bigFloat1p0, 1},
-		{bigRat2o1, complex1j0, 1},
-
-		// BigFloat less than every possible type.
-		{bigFloat0p0, int1, -1},
-		{bigFloat0p0, bigInt1, -1},
-		{bigFloat0p0, bigFloat1p0, -1},
-		{bigFloat0p0, complex1j0, -1},
-
-		// BigFloat equal to every possible type.
-		{bigFloat1p0, int1, 0},
-		{bigFloat1p0, bigInt1, 0},
-		{bigFloat1p0, bigFloat1p0, 0},
-		{bigFloat1p0, complex1j0, 0},
-
-		// BigFloat greater than every possible type.
-		{bigFloat2p0, int1, 1},
-		{bigFloat2p0, bigInt1, 1},
-		{bigFloat2p0, bigFloat1p0, 1},
-		{bigFloat2p0, complex1j0, 1},
-
-		// Special cases involving char and complex.
-
-		// Char is always less than every other type.
-		{char1, int1, -1},
-		{char1, bigInt1, -1},
-		{char1, bigRat1o1, -1},
-		{char1, bigFloat1p0, -1},
-		{char1, complex1j0, -1},
-
-		// Complex that is actually real is like a float.
-		{complex1j0, int1, 0},
-		{complex1j0, char1, 1}, // Note: can't compare with char. See next block of tests.
-		{complex1j0, bigInt1, 0},
-		{complex1j0, bigRat1o1, 0},
-		{complex1j0, bigFloat1p0, 0},
-
-		// Complex with imaginary part is always greater than every other type.
-		{complex1j1, int1, 1},
-		{complex1j1, char1, 1},
-		{complex1j1, bigInt1, 1},
-		{complex1j1, bigRat1o1, 1},
-		{complex1j1, bigFloat1p0, 1},
+	runOrderTests := func(t *testing.T, c value.Context, tests []orderTest) {
+		t.Helper()
+		for _, test := range tests {
+			got := value.OrderedCompare(c, test.u, test.v)
+			if got != test.sgn {
+				t.Errorf("orderedCompare(%T(%v), %T(%v)) = %d, expected %d", test.u, test.u, test.v, test.v, got, test.sgn)
+			}
+		}
 	}
+
+	t.Run("lex", func(t *testing.T) {
+		var testConf config.Config // zero value defaults to ComplexOrderLex
+		c := exec.NewContext(&testConf)
+		runOrderTests(t, c, commonTests)
+		runOrderTests(t, c, lexTests)
+	})
+	t.Run("magnitude", func(t *testing.T) {
+		var testConf config.Config
+		if err := testConf.SetComplexOrder("magnitude"); err != nil {
+			t.Fatal(err)
+		}
+		c := exec.NewContext(&testConf)
+		runOrderTests(t, c, commonTests)
+		runOrderTests(t, c, magnitudeTests)
+	})
+	t.Run("total", func(t *testing.T) {
+		var testConf config.Config
+		if err := testConf.SetComplexOrder("total"); err != nil {
+			t.Fatal(err)
+		}
+		c := exec.NewContext(&testConf)
+		runOrderTests(t, c, commonTests)
+		runOrderTests(t, c, totalTests)
+	})
+	t.Run("semver", func(t *testing.T) {
+		var testConf config.Config
+		c := exec.NewContext(&testConf)
+		runOrderTests(t, c, semverTests)
+	})
+}
+
+// TestOrderedCompareSemVerIncomparable checks that comparing a
+// SemVer with a numeric type reports an error instead of coercing
+// one into the other.
+func TestOrderedCompareSemVerIncomparable(t *testing.T) {
 	var testConf config.Config
 	c := exec.NewContext(&testConf)
+
+	compare := func(u, v value.Value) (sgn int, err error) {
+		defer value.Recover(&err)
+		sgn = value.OrderedCompare(c, u, v)
+		return
+	}
+
+	if _, err := compare(semverRelease, int1); err == nil {
+		t.Errorf("OrderedCompare(SemVer, Int) did not report an error")
+	}
+	if _, err := compare(int1, semverRelease); err == nil {
+		t.Errorf("OrderedCompare(Int, SemVer) did not report an error")
+	}
+}
+
+// TestSemVerConstraint exercises the range/constraint predicate ivy
+// would expose as `v in range "..."`.
+func TestSemVerConstraint(t *testing.T) {
+	con, err := value.ParseConstraint(">=1.2.0 <2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		v    string
+		want bool
+	}{
+		{"1.2.0", true},
+		{"1.5.3", true},
+		{"1.1.9", false},
+		{"2.0.0", false},
+		{"1.2.0-rc.1", false}, // Pre-release of 1.2.0 is below it.
+	}
 	for _, test := range tests {
-		got := value.OrderedCompare(c, test.u, test.v)
-		if got != test.sgn {
-			t.Errorf("orderedCompare(%T(%v), %T(%v)) = %d, expected %d", test.u, test.u, test.v, test.v, got, test.sgn)
+		if got := con.Match(mustParseSemVer(test.v)); got != test.want {
+			t.Errorf("Constraint(%q).Match(%s) = %v, want %v", ">=1.2.0 <2.0.0", test.v, got, test.want)
 		}
 	}
 }
@@ -0,0 +1,22 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+// BigFloat is an arbitrary-precision floating-point number, ivy's
+// representation for non-integral results that exceed native
+// float64 precision requirements.
+type BigFloat struct {
+	*big.Float
+}
+
+func (b BigFloat) String() string {
+	return b.Float.Text('g', -1)
+}
+
+func (b BigFloat) Kind() Kind {
+	return BigFloatKind
+}
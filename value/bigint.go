@@ -0,0 +1,21 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+// BigInt is an arbitrary-precision integer, used once a computation
+// overflows Int.
+type BigInt struct {
+	*big.Int
+}
+
+func (b BigInt) String() string {
+	return b.Int.String()
+}
+
+func (b BigInt) Kind() Kind {
+	return BigIntKind
+}
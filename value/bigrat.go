@@ -0,0 +1,20 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+// BigRat is an arbitrary-precision rational number.
+type BigRat struct {
+	*big.Rat
+}
+
+func (b BigRat) String() string {
+	return b.Rat.RatString()
+}
+
+func (b BigRat) Kind() Kind {
+	return BigRatKind
+}
@@ -0,0 +1,19 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "strconv"
+
+// Char is a single ivy character, holding one Unicode code point.
+// Chars sort before every numeric value type; see OrderedCompare.
+type Char rune
+
+func (c Char) String() string {
+	return strconv.QuoteRune(rune(c))
+}
+
+func (c Char) Kind() Kind {
+	return CharKind
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"math"
+	"math/big"
+
+	"robpike.io/ivy/config"
+)
+
+// cmpPrec is the binary precision used to promote mismatched numeric
+// representations (say a BigRat against a BigFloat) to a common type
+// for comparison.
+const cmpPrec = 256
+
+// toFloat promotes a scalar numeric Value (Int, BigInt, BigRat, or
+// BigFloat) to a *big.Float at cmpPrec bits.
+func toFloat(v Value) *big.Float {
+	switch v := v.(type) {
+	case Int:
+		return new(big.Float).SetPrec(cmpPrec).SetInt64(int64(v))
+	case BigInt:
+		return new(big.Float).SetPrec(cmpPrec).SetInt(v.Int)
+	case BigRat:
+		return new(big.Float).SetPrec(cmpPrec).SetRat(v.Rat)
+	case BigFloat:
+		return new(big.Float).SetPrec(cmpPrec).Set(v.Float)
+	}
+	panic("value: toFloat of non-numeric value")
+}
+
+// isNumeric reports whether v is one of the scalar numeric
+// representations: Int, BigInt, BigRat, or BigFloat.
+func isNumeric(v Value) bool {
+	switch v.(type) {
+	case Int, BigInt, BigRat, BigFloat:
+		return true
+	}
+	return false
+}
+
+// isZero reports whether the scalar numeric value v is the additive
+// identity. It is used to decide whether a Complex acts like a real
+// number.
+func isZero(v Value) bool {
+	switch v := v.(type) {
+	case Int:
+		return v == 0
+	case BigInt:
+		return v.Sign() == 0
+	case BigRat:
+		return v.Sign() == 0
+	case BigFloat:
+		return v.Sign() == 0
+	}
+	return false
+}
+
+// compareNumeric orders two scalar numeric values (Int, BigInt,
+// BigRat, BigFloat, in any combination) by mathematical value.
+// Both sides are normalized first so that, for instance, a BigInt
+// holding a small value and an Int holding the same value take the
+// cheap Int/Int path below instead of paying for a big.Float
+// promotion.
+func compareNumeric(u, v Value) int {
+	u, v = Normalize(u), Normalize(v)
+	if ui, ok := u.(Int); ok {
+		if vi, ok := v.(Int); ok {
+			switch {
+			case ui < vi:
+				return -1
+			case ui > vi:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return toFloat(u).Cmp(toFloat(v))
+}
+
+// totalOrderKey maps f to a uint64 that is monotonic with the IEEE
+// 754 totalOrder predicate: negative values (including -0 and
+// negative NaNs) come before positive ones, -0 comes before +0, and
+// NaNs are ordered consistently with their sign and payload.
+func totalOrderKey(f float64) uint64 {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | (1 << 63)
+}
+
+// totalOrderFloat compares a and b using the IEEE 754 totalOrder
+// predicate.
+func totalOrderFloat(a, b Value) int {
+	af, _ := toFloat(a).Float64()
+	bf, _ := toFloat(b).Float64()
+	ka, kb := totalOrderKey(af), totalOrderKey(bf)
+	switch {
+	case ka < kb:
+		return -1
+	case ka > kb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// exactRat returns the exact rational value of v and true when v is
+// Int, BigInt, or BigRat; it returns false for BigFloat, which may
+// not be exactly representable as desired.
+func exactRat(v Value) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case Int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case BigInt:
+		return new(big.Rat).SetInt(v.Int), true
+	case BigRat:
+		return v.Rat, true
+	}
+	return nil, false
+}
+
+// magnitudeSquared returns re²+im² as a *big.Rat, using exact
+// arithmetic whenever both components allow it, and ok reports
+// whether that was possible.
+func magnitudeSquared(re, im Value) (mag *big.Rat, ok bool) {
+	reR, ok1 := exactRat(re)
+	imR, ok2 := exactRat(im)
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	mag = new(big.Rat).Add(new(big.Rat).Mul(reR, reR), new(big.Rat).Mul(imR, imR))
+	return mag, true
+}
+
+// magnitudeSquaredFloat is the BigFloat fallback for magnitudeSquared.
+func magnitudeSquaredFloat(re, im Value) *big.Float {
+	reF, imF := toFloat(re), toFloat(im)
+	sq := new(big.Float).SetPrec(cmpPrec)
+	reSq := new(big.Float).SetPrec(cmpPrec).Mul(reF, reF)
+	imSq := new(big.Float).SetPrec(cmpPrec).Mul(imF, imF)
+	return sq.Add(reSq, imSq)
+}
+
+// argument returns atan2(im, re) as a float64, the angle used to
+// break magnitude ties between complex numbers.
+func argument(re, im Value) float64 {
+	reF, _ := toFloat(re).Float64()
+	imF, _ := toFloat(im).Float64()
+	return math.Atan2(imF, reF)
+}
+
+// compareComplexMagnitude orders a and b by |z|², tie-breaking on
+// argument, as selected by config.ComplexOrderMagnitude.
+func compareComplexMagnitude(a, b Complex) int {
+	if amag, ok := magnitudeSquared(a.re, a.im); ok {
+		if bmag, ok := magnitudeSquared(b.re, b.im); ok {
+			if c := amag.Cmp(bmag); c != 0 {
+				return c
+			}
+			return compareArgument(a, b)
+		}
+	}
+	c := magnitudeSquaredFloat(a.re, a.im).Cmp(magnitudeSquaredFloat(b.re, b.im))
+	if c != 0 {
+		return c
+	}
+	return compareArgument(a, b)
+}
+
+func compareArgument(a, b Complex) int {
+	aArg, bArg := argument(a.re, a.im), argument(b.re, b.im)
+	switch {
+	case aArg < bArg:
+		return -1
+	case aArg > bArg:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareComplexLex orders a and b by real part, then by imaginary
+// part, using cmp to compare each pair of components.
+func compareComplexLex(cmp func(u, v Value) int, a, b Complex) int {
+	if c := cmp(a.re, b.re); c != 0 {
+		return c
+	}
+	return cmp(a.im, b.im)
+}
+
+// compareComplex orders two Complex values according to order.
+func compareComplex(order config.ComplexOrder, a, b Complex) int {
+	switch order {
+	case config.ComplexOrderMagnitude:
+		return compareComplexMagnitude(a, b)
+	case config.ComplexOrderTotal:
+		return compareComplexLex(totalOrderFloat, a, b)
+	default:
+		return compareComplexLex(compareNumeric, a, b)
+	}
+}
+
+// compareComplexScalar orders the Complex a against the non-complex
+// numeric scalar v, treating v as a complex number with a zero
+// imaginary part.
+func compareComplexScalar(order config.ComplexOrder, a Complex, v Value) int {
+	return compareComplex(order, a, NewComplex(v, Int(0)))
+}
+
+// complexOrder reports the ComplexOrder configured on c, defaulting
+// to ComplexOrderLex (ivy's historical behavior) when c is nil.
+func complexOrder(c Context) config.ComplexOrder {
+	if c == nil {
+		return config.ComplexOrderLex
+	}
+	return c.Config().ComplexOrder()
+}
+
+// OrderedCompare returns -1, 0, or 1 as u is less than, equal to, or
+// greater than v.
+//
+// A SemVer compares only against another SemVer, by semver.org
+// precedence (see compareSemVer); comparing a SemVer with any other
+// type calls Errorf rather than coercing one into the other.
+//
+// Otherwise, Char values sort before every other type. Among the
+// numeric representations — Int, BigInt, BigRat, BigFloat, and
+// Complex — values compare by mathematical value regardless of
+// representation, so a BigRat equal to an Int compares equal to it.
+// A non-complex scalar compares as a Complex with a zero imaginary
+// part, so the rule selected by c's Config (see
+// config.Config.SetComplexOrder) governs every comparison that
+// involves a Complex: "lex" (the default, ivy's historical behavior)
+// orders by real part, then by imaginary part; "magnitude" orders by
+// |z|² with ties broken by argument; "total" applies an IEEE 754
+// totalOrder to each component.
+func OrderedCompare(c Context, u, v Value) int {
+	uSemVer, uIsSemVer := u.(SemVer)
+	vSemVer, vIsSemVer := v.(SemVer)
+	switch {
+	case uIsSemVer && vIsSemVer:
+		return compareSemVer(uSemVer, vSemVer)
+	case uIsSemVer:
+		Errorf("value: cannot compare SemVer with %s", v.Kind())
+		return 0
+	case vIsSemVer:
+		Errorf("value: cannot compare %s with SemVer", u.Kind())
+		return 0
+	}
+
+	uc, uIsChar := u.(Char)
+	vc, vIsChar := v.(Char)
+	switch {
+	case uIsChar && vIsChar:
+		switch {
+		case uc < vc:
+			return -1
+		case uc > vc:
+			return 1
+		default:
+			return 0
+		}
+	case uIsChar:
+		return -1
+	case vIsChar:
+		return 1
+	}
+
+	order := complexOrder(c)
+	uComplex, uIsComplex := u.(Complex)
+	vComplex, vIsComplex := v.(Complex)
+	switch {
+	case uIsComplex && vIsComplex:
+		return compareComplex(order, uComplex, vComplex)
+	case uIsComplex:
+		return compareComplexScalar(order, uComplex, v)
+	case vIsComplex:
+		return -compareComplexScalar(order, vComplex, u)
+	}
+
+	return compareNumeric(u, v)
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "fmt"
+
+// Complex is a complex number, represented as a pair of scalar
+// Values (each an Int, BigInt, BigRat, or BigFloat) for the real
+// and imaginary parts.
+type Complex struct {
+	re, im Value
+}
+
+// NewComplex returns the complex number re+im·i.
+func NewComplex(re, im Value) Complex {
+	return Complex{re: re, im: im}
+}
+
+// Real returns the real part of c.
+func (c Complex) Real() Value {
+	return c.re
+}
+
+// Imag returns the imaginary part of c.
+func (c Complex) Imag() Value {
+	return c.im
+}
+
+func (c Complex) String() string {
+	return fmt.Sprintf("(%sj%s)", c.re, c.im)
+}
+
+func (c Complex) Kind() Kind {
+	return ComplexKind
+}
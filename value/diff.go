@@ -0,0 +1,175 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DiffEntry records one mismatched element found by Diff.
+type DiffEntry struct {
+	// Index is the entry's zero-based position: a one-element slice
+	// for a Vector, or a [row, col] pair for a Matrix.
+	Index []int
+	A, B  Value
+	// Cmp is OrderedCompare(c, A, B); it is never 0, since equal
+	// elements are not recorded.
+	Cmp int
+}
+
+// DiffReport is the result of Diff: a, b compared element by
+// element, in the spirit of go-cmp's pluggable reporters.
+type DiffReport struct {
+	// ShapeA and ShapeB are the shapes Diff compared: nil for a
+	// scalar, a one-element slice for a Vector, or a [rows, cols]
+	// pair for a Matrix.
+	ShapeA, ShapeB []int
+
+	// Entries holds one DiffEntry per mismatched element; equal
+	// elements are omitted. Entries is empty when ShapeMismatch
+	// reports true, since Diff cannot walk incompatible shapes.
+	Entries []DiffEntry
+
+	// MinAbsDiff and MaxAbsDiff are the smallest and largest absolute
+	// numeric differences among mismatched elements. They are nil
+	// unless at least one mismatched pair was numeric (see absDiff);
+	// a Complex with a nonzero imaginary part does not contribute.
+	MinAbsDiff, MaxAbsDiff *big.Float
+}
+
+// ShapeMismatch reports whether a and b, as passed to Diff, had
+// incompatible shapes.
+func (r DiffReport) ShapeMismatch() bool {
+	return !intsEqual(r.ShapeA, r.ShapeB)
+}
+
+// Equal reports whether a and b had a compatible shape and no
+// mismatched elements.
+func (r DiffReport) Equal() bool {
+	return !r.ShapeMismatch() && len(r.Entries) == 0
+}
+
+// Diff compares a and b element by element and reports where they
+// disagree. a and b must be shape-compatible: both scalars, both
+// Vectors of the same length, or both Matrices with the same number
+// of rows and columns; otherwise ShapeMismatch reports true on the
+// returned DiffReport and Entries is empty.
+//
+// Because OrderedCompare already unifies the scalar types, Diff works
+// the same way across mixed-type arrays — an Int element compares
+// equal to a BigFloat element holding the same value, for instance.
+func Diff(c Context, a, b Value) DiffReport {
+	report := DiffReport{ShapeA: shapeOf(a), ShapeB: shapeOf(b)}
+	if report.ShapeMismatch() {
+		return report
+	}
+	switch a := a.(type) {
+	case Vector:
+		b := b.(Vector)
+		for i := range a {
+			report.addEntry(c, []int{i}, a[i], b[i])
+		}
+	case Matrix:
+		b := b.(Matrix)
+		for i, rowA := range a.Rows {
+			rowB := b.Rows[i]
+			for j := range rowA {
+				report.addEntry(c, []int{i, j}, rowA[j], rowB[j])
+			}
+		}
+	default:
+		report.addEntry(c, nil, a, b)
+	}
+	return report
+}
+
+func (r *DiffReport) addEntry(c Context, index []int, a, b Value) {
+	cmp := OrderedCompare(c, a, b)
+	if cmp == 0 {
+		return
+	}
+	r.Entries = append(r.Entries, DiffEntry{Index: index, A: a, B: b, Cmp: cmp})
+	if d, ok := absDiff(a, b); ok {
+		if r.MinAbsDiff == nil || d.Cmp(r.MinAbsDiff) < 0 {
+			r.MinAbsDiff = d
+		}
+		if r.MaxAbsDiff == nil || d.Cmp(r.MaxAbsDiff) > 0 {
+			r.MaxAbsDiff = d
+		}
+	}
+}
+
+// absDiff returns |a-b| when both a and b are numeric (after
+// Normalize collapses a zero-imaginary Complex to its real part);
+// ok is false otherwise.
+func absDiff(a, b Value) (diff *big.Float, ok bool) {
+	a, b = Normalize(a), Normalize(b)
+	if !isNumeric(a) || !isNumeric(b) {
+		return nil, false
+	}
+	d := new(big.Float).SetPrec(cmpPrec).Sub(toFloat(a), toFloat(b))
+	return d.Abs(d), true
+}
+
+// shapeOf returns a's shape as Diff understands it: nil for a
+// scalar, a one-element slice for a Vector, or a [rows, cols] pair
+// for a Matrix.
+func shapeOf(v Value) []int {
+	switch v := v.(type) {
+	case Vector:
+		return []int{len(v)}
+	case Matrix:
+		cols := 0
+		if len(v.Rows) > 0 {
+			cols = len(v.Rows[0])
+		}
+		return []int{len(v.Rows), cols}
+	}
+	return nil
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats r as a compact text diff: the compared shape
+// followed by one line per mismatched cell, each tagged with its
+// index — e.g. "5 7 ρ A vs B: 2 cell(s) differ" followed by lines
+// like "  [3 1] 2 vs 3". This is Diff's default reporter; a caller
+// that wants go-cmp-style pluggable output can walk r.Entries
+// instead.
+func (r DiffReport) String() string {
+	if r.ShapeMismatch() {
+		return fmt.Sprintf("%s ρ A vs %s ρ B: shape mismatch", shapeString(r.ShapeA), shapeString(r.ShapeB))
+	}
+	if len(r.Entries) == 0 {
+		return fmt.Sprintf("%s ρ A: no difference", shapeString(r.ShapeA))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s ρ A vs B: %d cell(s) differ\n", shapeString(r.ShapeA), len(r.Entries))
+	for _, e := range r.Entries {
+		fmt.Fprintf(&b, "  [%s] %s vs %s\n", shapeString(e.Index), e.A, e.B)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func shapeString(shape []int) string {
+	parts := make([]string, len(shape))
+	for i, n := range shape {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, " ")
+}
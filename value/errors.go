@@ -0,0 +1,45 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "fmt"
+
+// Error is the type of the panic value used to report a run-time
+// error from within the value package, such as comparing two
+// incomparable types. A caller that needs a plain error rather than
+// a panic should defer a call to Recover; see its doc comment.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Errorf formats according to the given format specifier and panics
+// with the resulting Error. It is used throughout the value package
+// to report a problem with the values being operated on, leaving it
+// to the caller — typically the evaluator's top-level loop — to
+// recover and report it.
+func Errorf(format string, args ...interface{}) {
+	panic(Error(fmt.Sprintf(format, args...)))
+}
+
+// Recover turns a panic with a value.Error into a returned error,
+// storing it in *errp; any other panic value propagates unchanged.
+// Defer it directly, since recover only takes effect when called by
+// the deferred function itself:
+//
+//	func Eval() (err error) {
+//		defer value.Recover(&err)
+//		...
+//	}
+func Recover(errp *error) {
+	if r := recover(); r != nil {
+		if e, ok := r.(Error); ok {
+			*errp = e
+			return
+		}
+		panic(r)
+	}
+}
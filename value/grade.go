@@ -0,0 +1,144 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "sort"
+
+// Grade returns the permutation, as a Vector of 1-based Ints (ivy's
+// indexing convention), that stably sorts v by OrderedCompare.
+// ascending selects ⍋ (grade up); false selects ⍒ (grade down). If v
+// is a Matrix, its rows are compared lexicographically column by
+// column, the first column acting as the primary key, exactly as
+// ⍋ does for a vector of vectors.
+//
+// collate, which may be nil, supplies a custom ordering for Char
+// elements, implementing the ⍋[C] form: a Char found in collate
+// sorts according to its position there; a Char not found in collate
+// sorts after every Char that is found, but — per OrderedCompare —
+// still before every non-Char value.
+//
+// sort.SliceStable already provides the stability Grade needs, so
+// there is no call here for a hand-rolled introsort/mergesort.
+func Grade(c Context, v Value, ascending bool, collate Vector) Value {
+	before := func(cmp int) bool {
+		if ascending {
+			return cmp < 0
+		}
+		return cmp > 0
+	}
+
+	switch v := v.(type) {
+	case Vector:
+		idx := identityIndex(len(v))
+		sort.SliceStable(idx, func(i, j int) bool {
+			return before(gradeCompare(c, collate, v[idx[i]], v[idx[j]]))
+		})
+		return gradeResult(idx)
+
+	case Matrix:
+		idx := identityIndex(len(v.Rows))
+		sort.SliceStable(idx, func(i, j int) bool {
+			return before(compareRows(c, collate, v.Rows[idx[i]], v.Rows[idx[j]]))
+		})
+		return gradeResult(idx)
+	}
+	panic("value: Grade of non-array value")
+}
+
+// identityIndex returns {0, 1, ..., n-1}, the starting point for a
+// Grade permutation.
+func identityIndex(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// gradeResult converts a 0-based permutation into the 1-based Vector
+// of Ints that Grade returns.
+func gradeResult(idx []int) Value {
+	out := make(Vector, len(idx))
+	for i, j := range idx {
+		out[i] = Int(j + 1)
+	}
+	return out
+}
+
+// compareRows orders two rows of a Matrix lexicographically, column
+// by column; a shorter row that is a prefix of a longer one sorts
+// first.
+func compareRows(c Context, collate Vector, a, b Vector) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if cmp := gradeCompare(c, collate, a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// gradeCompare is OrderedCompare, except that when both u and v are
+// Char and collate is non-nil, it consults collate for their
+// relative order instead of comparing code points directly.
+func gradeCompare(c Context, collate Vector, u, v Value) int {
+	if collate != nil {
+		if uc, ok := u.(Char); ok {
+			if vc, ok := v.(Char); ok {
+				return compareCollated(collate, uc, vc)
+			}
+		}
+	}
+	return OrderedCompare(c, u, v)
+}
+
+// compareCollated orders two Chars by their position in collate.
+// A Char absent from collate sorts after every Char present in it.
+func compareCollated(collate Vector, u, v Char) int {
+	ui, uFound := collateIndex(collate, u)
+	vi, vFound := collateIndex(collate, v)
+	switch {
+	case uFound && vFound:
+		switch {
+		case ui < vi:
+			return -1
+		case ui > vi:
+			return 1
+		default:
+			return 0
+		}
+	case uFound:
+		return -1
+	case vFound:
+		return 1
+	}
+	switch {
+	case u < v:
+		return -1
+	case u > v:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func collateIndex(collate Vector, c Char) (int, bool) {
+	for i, elem := range collate {
+		if ch, ok := elem.(Char); ok && ch == c {
+			return i, true
+		}
+	}
+	return 0, false
+}
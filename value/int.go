@@ -0,0 +1,19 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "strconv"
+
+// Int is ivy's default integer representation. Arithmetic that would
+// overflow an int64 promotes to BigInt.
+type Int int64
+
+func (i Int) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+func (i Int) Kind() Kind {
+	return IntKind
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+// Kind reports the concrete representation of a Value, mirroring
+// constant.Kind from the standard library's go/constant package so
+// callers can branch on representation without a type switch.
+type Kind int
+
+const (
+	// IntKind is the Kind of an Int.
+	IntKind Kind = iota
+	// CharKind is the Kind of a Char.
+	CharKind
+	// BigIntKind is the Kind of a BigInt.
+	BigIntKind
+	// BigRatKind is the Kind of a BigRat.
+	BigRatKind
+	// BigFloatKind is the Kind of a BigFloat.
+	BigFloatKind
+	// ComplexKind is the Kind of a Complex.
+	ComplexKind
+	// VectorKind is the Kind of a Vector.
+	VectorKind
+	// MatrixKind is the Kind of a Matrix.
+	MatrixKind
+	// SemVerKind is the Kind of a SemVer.
+	SemVerKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case IntKind:
+		return "Int"
+	case CharKind:
+		return "Char"
+	case BigIntKind:
+		return "BigInt"
+	case BigRatKind:
+		return "BigRat"
+	case BigFloatKind:
+		return "BigFloat"
+	case ComplexKind:
+		return "Complex"
+	case VectorKind:
+		return "Vector"
+	case MatrixKind:
+		return "Matrix"
+	case SemVerKind:
+		return "SemVer"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "strings"
+
+// Matrix is ivy's two-dimensional array of Values, stored as a slice
+// of equal-length rows.
+type Matrix struct {
+	Rows []Vector
+}
+
+func (m Matrix) String() string {
+	rows := make([]string, len(m.Rows))
+	for i, row := range m.Rows {
+		rows[i] = row.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Matrix) Kind() Kind {
+	return MatrixKind
+}
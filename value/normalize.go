@@ -0,0 +1,39 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "math/big"
+
+// Normalize demotes v to the smallest representation that holds the
+// same mathematical value, the way go/constant normalizes numeric
+// constants after every operation: a BigInt that fits in an int64
+// becomes an Int; a BigRat with denominator 1 becomes a BigInt (and,
+// in turn, an Int, if it fits); a BigFloat that is exactly integral
+// becomes a BigInt (or Int); a Complex with a zero imaginary part
+// collapses to its (normalized) real component. Every other Value,
+// including one already in its smallest form, is returned unchanged.
+func Normalize(v Value) Value {
+	switch v := v.(type) {
+	case BigInt:
+		if v.IsInt64() {
+			return Int(v.Int64())
+		}
+	case BigRat:
+		if v.IsInt() {
+			return Normalize(BigInt{new(big.Int).Set(v.Num())})
+		}
+	case BigFloat:
+		if v.IsInt() {
+			if i, acc := v.Int(nil); acc == big.Exact {
+				return Normalize(BigInt{i})
+			}
+		}
+	case Complex:
+		if isZero(v.im) {
+			return Normalize(v.re)
+		}
+	}
+	return v
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a semantic version as defined by semver.org: a
+// major.minor.patch triple, optional dot-separated pre-release
+// identifiers, and optional dot-separated build metadata. It is
+// deliberately not a numeric type: OrderedCompare refuses to compare
+// a SemVer with an Int, BigInt, BigRat, BigFloat, or Complex rather
+// than coerce one into the other.
+type SemVer struct {
+	Major, Minor, Patch int64
+	Pre                 []string
+	Build               []string
+}
+
+func (v SemVer) Kind() Kind {
+	return SemVerKind
+}
+
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Pre) > 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// ParseSemVer parses a semantic version string such as
+// "1.2.3-rc.1+build.7". Build metadata, after a "+", is preserved for
+// display but ignored by OrderedCompare.
+func ParseSemVer(s string) (SemVer, error) {
+	orig := s
+	var build string
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s, build = s[:i], s[i+1:]
+	}
+	core, pre := s, ""
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		core, pre = s[:i], s[i+1:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return SemVer{}, fmt.Errorf("value: malformed semantic version %q", orig)
+	}
+	var nums [3]int64
+	for i, p := range parts {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || n < 0 {
+			return SemVer{}, fmt.Errorf("value: malformed semantic version %q", orig)
+		}
+		nums[i] = n
+	}
+	v := SemVer{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+	if pre != "" {
+		v.Pre = strings.Split(pre, ".")
+	}
+	if build != "" {
+		v.Build = strings.Split(build, ".")
+	}
+	return v, nil
+}
+
+// compareSemVer orders two SemVer values following semver.org's
+// precedence rules: the major.minor.patch triple compares
+// numerically; a pre-release version has lower precedence than the
+// associated normal version; and when both have pre-release
+// identifiers, they compare field by field, numeric identifiers
+// numerically and alphanumeric identifiers lexicographically, with
+// numeric identifiers always ranking lower than alphanumeric ones.
+// Build metadata does not affect precedence.
+func compareSemVer(a, b SemVer) int {
+	if c := compareInt64(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareInt64(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt64(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(a.Pre) == 0 && len(b.Pre) == 0:
+		return 0
+	case len(a.Pre) == 0:
+		return 1 // a is the normal version, b is a pre-release of it.
+	case len(b.Pre) == 0:
+		return -1
+	}
+	n := len(a.Pre)
+	if len(b.Pre) < n {
+		n = len(b.Pre)
+	}
+	for i := 0; i < n; i++ {
+		if c := comparePreReleaseIdent(a.Pre[i], b.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt64(int64(len(a.Pre)), int64(len(b.Pre)))
+}
+
+// comparePreReleaseIdent compares one dot-separated pre-release
+// identifier: numeric identifiers compare numerically and rank lower
+// than any alphanumeric identifier; alphanumeric identifiers compare
+// lexicographically in ASCII order.
+func comparePreReleaseIdent(a, b string) int {
+	an, aIsNum := numericIdent(a)
+	bn, bIsNum := numericIdent(b)
+	switch {
+	case aIsNum && bIsNum:
+		return compareInt64(an, bn)
+	case aIsNum:
+		return -1
+	case bIsNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func numericIdent(s string) (int64, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
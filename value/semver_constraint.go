@@ -0,0 +1,77 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a parsed semver range predicate: a conjunction of
+// comparator clauses such as ">=1.2.0 <2.0.0", every one of which
+// must hold for Match to report true. It lets ivy be used as a
+// scratch calculator for dependency-resolution experiments, e.g.
+// `v in range ">=1.2.0 <2.0.0"`.
+type Constraint struct {
+	clauses []constraintClause
+}
+
+type constraintClause struct {
+	op string // one of "=", "<", "<=", ">", ">="
+	v  SemVer
+}
+
+// ParseConstraint parses a whitespace-separated sequence of
+// comparator clauses, each a comparison operator ("=", "<", "<=",
+// ">", ">=", or omitted, which means "=") immediately followed by a
+// semantic version, e.g. ">=1.2.0 <2.0.0".
+func ParseConstraint(s string) (Constraint, error) {
+	var con Constraint
+	for _, field := range strings.Fields(s) {
+		op, rest := splitConstraintOp(field)
+		v, err := ParseSemVer(rest)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("value: malformed constraint %q: %v", s, err)
+		}
+		con.clauses = append(con.clauses, constraintClause{op: op, v: v})
+	}
+	if len(con.clauses) == 0 {
+		return Constraint{}, fmt.Errorf("value: empty constraint %q", s)
+	}
+	return con, nil
+}
+
+func splitConstraintOp(field string) (op, rest string) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(field, candidate) {
+			return candidate, field[len(candidate):]
+		}
+	}
+	return "=", field
+}
+
+// Match reports whether v satisfies every clause of c.
+func (c Constraint) Match(v SemVer) bool {
+	for _, cl := range c.clauses {
+		cmp := compareSemVer(v, cl.v)
+		var ok bool
+		switch cl.op {
+		case "=":
+			ok = cmp == 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package value defines the scalar value types ivy computes with —
+// Int, Char, BigInt, BigRat, BigFloat, and Complex — along with the
+// operations, such as OrderedCompare, that treat them uniformly.
+package value
+
+import "robpike.io/ivy/config"
+
+// Value is satisfied by every ivy scalar.
+type Value interface {
+	// String returns the ivy-syntax representation of the value.
+	String() string
+	// Kind reports the value's concrete representation, letting
+	// callers branch without a type switch.
+	Kind() Kind
+}
+
+// Context is the part of exec.Context that the value package needs:
+// access to the active Config. It is declared here, rather than
+// imported from package exec, so that value depends only on config;
+// *exec.Context satisfies it.
+type Context interface {
+	Config() *config.Config
+}
@@ -0,0 +1,22 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package value
+
+import "strings"
+
+// Vector is ivy's one-dimensional array of Values.
+type Vector []Value
+
+func (v Vector) String() string {
+	parts := make([]string, len(v))
+	for i, elem := range v {
+		parts[i] = elem.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (v Vector) Kind() Kind {
+	return VectorKind
+}